@@ -0,0 +1,457 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	_ "net/http/pprof"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/OnlyOneCookie/.transfer/engine"
+	"github.com/OnlyOneCookie/.transfer/units"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// flushRows is how many rows a worker processes between partial snapshots
+// of its station table, so --interval has fresh data to render.
+const flushRows = 50_000
+
+// mappedFile abstracts the backing store for the input file: mmap(2) on
+// platforms that support it, a plain ReadAt-based buffer elsewhere.
+type mappedFile interface {
+	Bytes() []byte
+	Close() error
+}
+
+// Station holds the final statistics for output
+type Station struct {
+	Name string  `json:"name"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+// StationOutput is used for JSON file output
+type StationOutput struct {
+	Stations []Station `json:"stations"`
+	Stats    struct {
+		ProcessingTime     string  `json:"processing_time"`
+		ProcessingTimeMs   int64   `json:"processing_time_ms"`
+		FileSize           int64   `json:"file_size_bytes"`
+		FileSizeHuman      string  `json:"file_size_human"`
+		NumStations        int     `json:"number_of_stations"`
+		RowsProcessed      int64   `json:"rows_processed"`
+		RowsPerSecond      float64 `json:"rows_per_second"`
+		BytesPerSecond     float64 `json:"bytes_per_second"`
+		HeapAllocPeakBytes uint64  `json:"heap_alloc_peak_bytes"`
+		NumGC              uint32  `json:"num_gc"`
+		PhaseTimings       struct {
+			ReadMs  int64 `json:"read_ms"`
+			ParseMs int64 `json:"parse_ms"`
+			MergeMs int64 `json:"merge_ms"`
+			WriteMs int64 `json:"write_ms"`
+		} `json:"phase_timings"`
+	} `json:"stats"`
+}
+
+// SnapshotOutput is the JSON object written to stdout once per --interval
+// tick when --json is set, while chunks are still being processed.
+type SnapshotOutput struct {
+	ElapsedMs      int64     `json:"elapsed_ms"`
+	RowsProcessed  int64     `json:"rows_processed"`
+	RowsPerSecond  float64   `json:"rows_per_second"`
+	BytesPerSecond float64   `json:"bytes_per_second"`
+	Stations       []Station `json:"stations"`
+}
+
+// processChunk scans a newline-aligned byte range of the mapped input,
+// tracking activeWorkers and chunkDuration around the shared engine scan.
+// Every flushRows rows (and once more at the end) the delta table engine
+// hands back is pushed to snapshots and added to the shared row/byte
+// counters, so the rest of the program can observe live progress.
+func processChunk(data []byte, snapshots chan<- *engine.StationTable, rowsCounter, bytesCounter *int64) *engine.StationTable {
+	activeWorkers.Inc()
+	defer activeWorkers.Dec()
+	chunkStart := time.Now()
+	defer func() { chunkDuration.Observe(time.Since(chunkStart).Seconds()) }()
+
+	return engine.ScanChunk(data, flushRows,
+		func(delta *engine.StationTable, rows, bytesRead int) bool {
+			atomic.AddInt64(rowsCounter, int64(rows))
+			atomic.AddInt64(bytesCounter, int64(bytesRead))
+			rowsProcessedTotal.Add(float64(rows))
+			bytesReadTotal.Add(float64(bytesRead))
+			snapshots <- delta
+			return true
+		},
+		parseErrorsTotal.Inc,
+	)
+}
+
+// resultsToStations converts engine's merged results into the CLI's own
+// output shape, which carries json tags the engine type doesn't need.
+func resultsToStations(results map[string]*engine.Result) []Station {
+	engineStations := engine.StationsFromResults(results)
+	stations := make([]Station, len(engineStations))
+	for i, s := range engineStations {
+		stations[i] = Station{Name: s.Name, Min: s.Min, Max: s.Max, Mean: s.Mean}
+	}
+	return stations
+}
+
+func main() {
+	interval := flag.Duration("interval", 200*time.Millisecond, "how often to flush partial results while processing")
+	jsonOutput := flag.Bool("json", false, "emit snapshots (and the final result) as one JSON object per line")
+	summary := flag.Bool("summary", false, "suppress intermediate snapshots and print only the final result")
+	metricsAddr := flag.String("metrics-addr", "", "address to serve Prometheus metrics on (e.g. :9090); if set, the process keeps running after results.json is written until SIGINT")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile of the chunk-processing phase to this file")
+	memProfile := flag.String("memprofile", "", "write a heap profile taken right after chunk processing to this file")
+	traceFile := flag.String("trace", "", "write an execution trace of the chunk-processing phase to this file")
+	pprofAddr := flag.String("pprof-addr", "", "address to mount net/http/pprof on (e.g. :6060); if set, the process keeps running after results.json is written until SIGINT")
+	chunkSizeFlag := flag.String("chunk-size", "", "target size per worker chunk (e.g. 16MiB); defaults to splitting evenly across GOMAXPROCS workers")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Println("Usage: program [--interval=200ms] [--json] [--summary] [--metrics-addr=:9090] [--cpuprofile=FILE] [--memprofile=FILE] [--trace=FILE] [--pprof-addr=:6060] [--chunk-size=16MiB] <inputfile>")
+		os.Exit(1)
+	}
+
+	if !*summary && *interval <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid --interval: %s must be positive (use --summary to disable periodic snapshots)\n", interval)
+		os.Exit(1)
+	}
+
+	var chunkSizeOverride int64
+	if *chunkSizeFlag != "" {
+		var err error
+		chunkSizeOverride, err = units.ParseByteSize(*chunkSizeFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid --chunk-size: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			if err := http.ListenAndServe(*metricsAddr, mux); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintf(os.Stderr, "metrics server: %v\n", err)
+			}
+		}()
+	}
+
+	if *pprofAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(*pprofAddr, nil); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintf(os.Stderr, "pprof server: %v\n", err)
+			}
+		}()
+	}
+
+	startTime := time.Now()
+	readStart := startTime
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		panic(err)
+	}
+	fileSize := fileInfo.Size()
+
+	mapped, err := openMapped(file, fileSize)
+	if err != nil {
+		panic(err)
+	}
+	defer mapped.Close()
+	data := mapped.Bytes()
+	readDuration := time.Since(readStart)
+	parseStart := time.Now()
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	chunks := engine.SplitChunks(data, numWorkers, chunkSizeOverride)
+
+	jobs := make(chan engine.Chunk, len(chunks))
+	snapshots := make(chan *engine.StationTable, numWorkers*4)
+
+	var rowsProcessed int64
+	var bytesProcessed int64
+
+	var memStatsBefore runtime.MemStats
+	runtime.ReadMemStats(&memStatsBefore)
+	var heapAllocPeak uint64 = memStatsBefore.HeapAlloc
+
+	if *cpuProfile != "" {
+		f, err := os.Create(*cpuProfile)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			panic(err)
+		}
+	}
+
+	var traceFileHandle *os.File
+	if *traceFile != "" {
+		f, err := os.Create(*traceFile)
+		if err != nil {
+			panic(err)
+		}
+		traceFileHandle = f
+		if err := trace.Start(f); err != nil {
+			panic(err)
+		}
+	}
+
+	memSamplerDone := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		var ms runtime.MemStats
+		for {
+			select {
+			case <-ticker.C:
+				runtime.ReadMemStats(&ms)
+				for {
+					old := atomic.LoadUint64(&heapAllocPeak)
+					if ms.HeapAlloc <= old || atomic.CompareAndSwapUint64(&heapAllocPeak, old, ms.HeapAlloc) {
+						break
+					}
+				}
+			case <-memSamplerDone:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range jobs {
+				processChunk(data[c.Start:c.End], snapshots, &rowsProcessed, &bytesProcessed)
+			}
+		}()
+	}
+
+	for _, c := range chunks {
+		jobs <- c
+	}
+	close(jobs)
+
+	go func() {
+		wg.Wait()
+		close(snapshots)
+	}()
+
+	var aggMu sync.RWMutex
+	finalResults := make(map[string]*engine.Result)
+
+	aggregatorDone := make(chan struct{})
+	go func() {
+		for delta := range snapshots {
+			aggMu.Lock()
+			engine.MergeTableInto(finalResults, delta)
+			stationsSeen.Set(float64(len(finalResults)))
+			aggMu.Unlock()
+		}
+		close(aggregatorDone)
+	}()
+
+	renderSnapshot := func() {
+		aggMu.RLock()
+		stations := resultsToStations(finalResults)
+		aggMu.RUnlock()
+
+		elapsed := time.Since(startTime).Seconds()
+		rows := atomic.LoadInt64(&rowsProcessed)
+		bytesRead := atomic.LoadInt64(&bytesProcessed)
+
+		snap := SnapshotOutput{
+			ElapsedMs:      time.Since(startTime).Milliseconds(),
+			RowsProcessed:  rows,
+			RowsPerSecond:  float64(rows) / elapsed,
+			BytesPerSecond: float64(bytesRead) / elapsed,
+			Stations:       stations,
+		}
+
+		if *jsonOutput {
+			line, err := json.Marshal(snap)
+			if err == nil {
+				fmt.Println(string(line))
+			}
+			return
+		}
+		fmt.Printf("[%s] rows=%d rows/sec=%.0f bytes/sec=%s/s stations=%d\n",
+			time.Since(startTime).Round(time.Millisecond), rows, snap.RowsPerSecond,
+			units.ByteSize(int64(snap.BytesPerSecond)), len(stations))
+	}
+
+	tickerDone := make(chan struct{})
+	if !*summary {
+		go func() {
+			ticker := time.NewTicker(*interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					renderSnapshot()
+				case <-tickerDone:
+					return
+				}
+			}
+		}()
+	}
+
+	<-aggregatorDone
+	close(tickerDone)
+	close(memSamplerDone)
+	parseDuration := time.Since(parseStart)
+
+	if *cpuProfile != "" {
+		pprof.StopCPUProfile()
+	}
+	if *traceFile != "" {
+		trace.Stop()
+		traceFileHandle.Close()
+	}
+
+	var memStatsAfter runtime.MemStats
+	runtime.ReadMemStats(&memStatsAfter)
+	if memStatsAfter.HeapAlloc > atomic.LoadUint64(&heapAllocPeak) {
+		heapAllocPeak = memStatsAfter.HeapAlloc
+	}
+
+	if *memProfile != "" {
+		f, err := os.Create(*memProfile)
+		if err != nil {
+			panic(err)
+		}
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			panic(err)
+		}
+		f.Close()
+	}
+
+	mergeStart := time.Now()
+	stations := resultsToStations(finalResults)
+	mergeDuration := time.Since(mergeStart)
+
+	totalRows := atomic.LoadInt64(&rowsProcessed)
+	totalBytes := atomic.LoadInt64(&bytesProcessed)
+	elapsed := time.Since(startTime)
+
+	// Prepare output
+	output := StationOutput{
+		Stations: stations,
+	}
+	output.Stats.ProcessingTime = elapsed.String()
+	output.Stats.ProcessingTimeMs = elapsed.Milliseconds()
+	output.Stats.FileSize = fileSize
+	output.Stats.FileSizeHuman = units.ByteSize(fileSize)
+	output.Stats.NumStations = len(stations)
+	output.Stats.RowsProcessed = totalRows
+	output.Stats.RowsPerSecond = float64(totalRows) / elapsed.Seconds()
+	output.Stats.HeapAllocPeakBytes = heapAllocPeak
+	output.Stats.NumGC = memStatsAfter.NumGC - memStatsBefore.NumGC
+	output.Stats.BytesPerSecond = float64(totalBytes) / elapsed.Seconds()
+	output.Stats.PhaseTimings.ReadMs = readDuration.Milliseconds()
+	output.Stats.PhaseTimings.ParseMs = parseDuration.Milliseconds()
+	output.Stats.PhaseTimings.MergeMs = mergeDuration.Milliseconds()
+
+	// Write to JSON file. write_ms can't include the time to write its own
+	// value, so marshal once to measure the write, then once more with
+	// PhaseTimings.WriteMs filled in.
+	writeStart := time.Now()
+	jsonData, err := json.MarshalIndent(output, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("results.json", jsonData, 0644); err != nil {
+		panic(err)
+	}
+	output.Stats.PhaseTimings.WriteMs = time.Since(writeStart).Milliseconds()
+
+	jsonData, err = json.MarshalIndent(output, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("results.json", jsonData, 0644); err != nil {
+		panic(err)
+	}
+
+	if *jsonOutput {
+		line, err := json.Marshal(output)
+		if err == nil {
+			fmt.Println(string(line))
+		}
+	} else {
+		// Console output
+		fmt.Println("\nðŸŒ 1BRC (One Billion Row Challenge) Results")
+		fmt.Println("==========================================")
+		fmt.Printf("Input File Size: %s\n", units.ByteSize(fileSize))
+		fmt.Printf("Number of Stations: %d\n", len(stations))
+		fmt.Printf("Processing Time: %s\n", output.Stats.ProcessingTime)
+		fmt.Printf("Rows Processed: %d (%.0f rows/sec)\n", totalRows, output.Stats.RowsPerSecond)
+		fmt.Println("\nTop 5 Stations by Temperature Range:")
+		fmt.Println("------------------------------------")
+
+		// Sort by temperature range for display
+		sort.Slice(stations, func(i, j int) bool {
+			rangeI := stations[i].Max - stations[i].Min
+			rangeJ := stations[j].Max - stations[j].Min
+			return rangeJ < rangeI
+		})
+
+		for i := 0; i < min(5, len(stations)); i++ {
+			station := stations[i]
+			fmt.Printf("%s:\n", station.Name)
+			fmt.Printf("  Min: %.1fÂ°C\n", station.Min)
+			fmt.Printf("  Max: %.1fÂ°C\n", station.Max)
+			fmt.Printf("  Mean: %.1fÂ°C\n", station.Mean)
+			fmt.Printf("  Range: %.1fÂ°C\n", station.Max-station.Min)
+			if i < min(4, len(stations)-1) {
+				fmt.Println()
+			}
+		}
+
+		fmt.Println("\nâœ… Full results have been written to results.json")
+	}
+
+	if *metricsAddr != "" || *pprofAddr != "" {
+		if *metricsAddr != "" {
+			fmt.Printf("\nServing Prometheus metrics on %s until SIGINT...\n", *metricsAddr)
+		}
+		if *pprofAddr != "" {
+			fmt.Printf("\nServing net/http/pprof on %s until SIGINT...\n", *pprofAddr)
+		}
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		<-sigChan
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus metrics for the CLI benchmark run, scraped via --metrics-addr
+// so throughput and parse stats can be compared across machines without
+// parsing stdout.
+var (
+	rowsProcessedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "brc_rows_processed_total",
+		Help: "Total number of input rows parsed.",
+	})
+	bytesReadTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "brc_bytes_read_total",
+		Help: "Total number of input bytes scanned.",
+	})
+	parseErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "brc_parse_errors_total",
+		Help: "Total number of rows skipped for failing to parse.",
+	})
+	chunkDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "brc_chunk_duration_seconds",
+		Help:    "Time taken by a worker to scan one chunk.",
+		Buckets: prometheus.ExponentialBuckets(0.0005, 2, 16),
+	})
+	activeWorkers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "brc_active_workers",
+		Help: "Number of workers currently scanning a chunk.",
+	})
+	stationsSeen = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "brc_stations_seen",
+		Help: "Number of distinct stations observed so far.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		rowsProcessedTotal,
+		bytesReadTotal,
+		parseErrorsTotal,
+		chunkDuration,
+		activeWorkers,
+		stationsSeen,
+	)
+}
@@ -0,0 +1,33 @@
+//go:build !unix
+
+package main
+
+import (
+	"io"
+	"os"
+)
+
+// readAtMappedFile is the fallback backend for platforms where mmap(2)
+// isn't available: it reads the whole region into a plain heap buffer
+// and exposes the same mappedFile interface.
+type readAtMappedFile struct {
+	data []byte
+}
+
+// openMapped reads the first size bytes of f into memory for read-only access.
+func openMapped(f *os.File, size int64) (mappedFile, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(io.NewSectionReader(f, 0, size), data); err != nil {
+		return nil, err
+	}
+	return &readAtMappedFile{data: data}, nil
+}
+
+func (m *readAtMappedFile) Bytes() []byte {
+	return m.data
+}
+
+func (m *readAtMappedFile) Close() error {
+	m.data = nil
+	return nil
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// unixMappedFile is a mmap(2)-backed view of a file's contents.
+type unixMappedFile struct {
+	data []byte
+}
+
+// openMapped maps the first size bytes of f into memory for read-only access.
+func openMapped(f *os.File, size int64) (mappedFile, error) {
+	if size == 0 {
+		return &unixMappedFile{}, nil
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	return &unixMappedFile{data: data}, nil
+}
+
+func (m *unixMappedFile) Bytes() []byte {
+	return m.data
+}
+
+func (m *unixMappedFile) Close() error {
+	if m.data == nil {
+		return nil
+	}
+	return syscall.Munmap(m.data)
+}
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	goruntime "runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/OnlyOneCookie/.transfer/engine"
+	"github.com/OnlyOneCookie/.transfer/units"
+	"github.com/stergiotis/boxer/public/fffi/runtime"
+	"github.com/stergiotis/boxer/public/imzero/application"
+	"github.com/stergiotis/boxer/public/imzero/imgui"
+)
+
+// sortOptions are the fields the stations table can be ordered by, shown
+// via the sort combo in renderUI.
+var sortOptions = []string{"Name", "Min", "Max", "Mean", "Range", "Count"}
+
+// sparklineSamples is how many per-tick throughput samples the rolling
+// sparkline keeps.
+const sparklineSamples = 200
+
+// publishHz is the rate at which the processing pipeline publishes
+// partial aggregates into AppState for the UI to render.
+const publishHz = 20
+
+// flushRows is how many rows a worker scans between checking ctx for
+// cancellation and publishing a partial snapshot.
+const flushRows = 20_000
+
+// throughputRing is a fixed-size ring buffer of per-tick rows/sec samples
+// backing the sparkline.
+type throughputRing struct {
+	samples [sparklineSamples]float32
+	next    int
+	filled  bool
+}
+
+func (r *throughputRing) add(v float32) {
+	r.samples[r.next] = v
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.filled = true
+	}
+}
+
+// values returns the samples in chronological order, oldest first.
+func (r *throughputRing) values() []float32 {
+	if !r.filled {
+		out := make([]float32, r.next)
+		copy(out, r.samples[:r.next])
+		return out
+	}
+	out := make([]float32, len(r.samples))
+	copy(out, r.samples[r.next:])
+	copy(out[len(r.samples)-r.next:], r.samples[:r.next])
+	return out
+}
+
+// GUI state
+type AppState struct {
+	mu sync.RWMutex
+
+	stations []engine.Station
+	sortBy   int
+
+	inputFile    [256]byte
+	error        string
+	isProcessing bool
+	cancel       context.CancelFunc
+
+	fileSize      int64
+	fileSizeHuman string
+	bytesRead     int64
+	rowsProcessed int64
+	rowsPerSecond float64
+	startTime     time.Time
+	throughput    throughputRing
+	phaseTimings  phaseTimings
+}
+
+// phaseTimings mirrors the CLI's phase_timings: how long each stage of a
+// run took, so renderUI can show where time went.
+type phaseTimings struct {
+	readMs  int64
+	parseMs int64
+	mergeMs int64
+}
+
+var state = &AppState{
+	stations:     make([]engine.Station, 0),
+	isProcessing: false,
+}
+
+// Initialize the input file with default value
+func init() {
+	copy(state.inputFile[:], "measurements.txt")
+}
+
+// processChunk runs the shared engine scan over a newline-aligned byte
+// range, checking ctx for cancellation on every flush so a Cancel click
+// stops work promptly, and publishing each delta to snapshots.
+func processChunk(ctx context.Context, data []byte, snapshots chan<- *engine.StationTable, rowsCounter, bytesCounter *int64) *engine.StationTable {
+	return engine.ScanChunk(data, flushRows,
+		func(delta *engine.StationTable, rows, bytesRead int) bool {
+			atomic.AddInt64(rowsCounter, int64(rows))
+			atomic.AddInt64(bytesCounter, int64(bytesRead))
+			snapshots <- delta
+			return ctx.Err() == nil
+		},
+		nil,
+	)
+}
+
+// calculateStats runs the same chunked, parallel pipeline as the CLI:
+// the file is split into newline-aligned chunks, a fixed pool of workers
+// scans them concurrently, and partial aggregates are merged and
+// published into AppState at publishHz so renderUI always has fresh
+// progress to show. ctx cancellation (wired to the Cancel button) stops
+// workers promptly instead of waiting for the whole file to be scanned.
+func calculateStats(ctx context.Context, filename string) error {
+	state.mu.Lock()
+	state.error = ""
+	state.stations = nil
+	state.bytesRead = 0
+	state.rowsProcessed = 0
+	state.rowsPerSecond = 0
+	state.startTime = time.Now()
+	state.throughput = throughputRing{}
+	state.mu.Unlock()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %v", err)
+	}
+	fileSize := info.Size()
+	state.mu.Lock()
+	state.fileSize = fileSize
+	state.fileSizeHuman = units.ByteSize(fileSize)
+	state.mu.Unlock()
+
+	readStart := time.Now()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("error reading file: %v", err)
+	}
+	readMs := time.Since(readStart).Milliseconds()
+
+	parseStart := time.Now()
+	numWorkers := goruntime.GOMAXPROCS(0)
+	chunks := engine.SplitChunks(data, numWorkers, 0)
+
+	jobs := make(chan engine.Chunk, len(chunks))
+	snapshots := make(chan *engine.StationTable, numWorkers*4)
+
+	var rowsCounter, bytesCounter int64
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				processChunk(ctx, data[c.Start:c.End], snapshots, &rowsCounter, &bytesCounter)
+			}
+		}()
+	}
+
+	for _, c := range chunks {
+		jobs <- c
+	}
+	close(jobs)
+
+	go func() {
+		wg.Wait()
+		close(snapshots)
+	}()
+
+	merged := make(map[string]*engine.Result)
+	var mergedMu sync.Mutex
+
+	publishDone := make(chan struct{})
+	publisherStopped := make(chan struct{})
+	var lastRows int64
+	go func() {
+		defer close(publisherStopped)
+		ticker := time.NewTicker(time.Second / publishHz)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				publishSnapshot(&mergedMu, merged, &rowsCounter, &bytesCounter, &lastRows)
+			case <-publishDone:
+				return
+			}
+		}
+	}()
+
+	for delta := range snapshots {
+		mergedMu.Lock()
+		engine.MergeTableInto(merged, delta)
+		mergedMu.Unlock()
+	}
+	close(publishDone)
+	<-publisherStopped
+	parseMs := time.Since(parseStart).Milliseconds()
+
+	mergeStart := time.Now()
+	publishSnapshot(&mergedMu, merged, &rowsCounter, &bytesCounter, &lastRows)
+	mergeMs := time.Since(mergeStart).Milliseconds()
+
+	state.mu.Lock()
+	state.phaseTimings = phaseTimings{readMs: readMs, parseMs: parseMs, mergeMs: mergeMs}
+	state.mu.Unlock()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// publishSnapshot copies the current merged aggregate and counters into
+// AppState under its mutex, recording one more sparkline sample.
+func publishSnapshot(mergedMu *sync.Mutex, merged map[string]*engine.Result, rowsCounter, bytesCounter *int64, lastRows *int64) {
+	mergedMu.Lock()
+	stations := engine.StationsFromResults(merged)
+	mergedMu.Unlock()
+
+	rows := atomic.LoadInt64(rowsCounter)
+	bytesRead := atomic.LoadInt64(bytesCounter)
+
+	elapsed := time.Since(state.startTime).Seconds()
+	tickRate := float32(rows-*lastRows) * publishHz
+	*lastRows = rows
+
+	state.mu.Lock()
+	state.stations = stations
+	state.bytesRead = bytesRead
+	state.rowsProcessed = rows
+	if elapsed > 0 {
+		state.rowsPerSecond = float64(rows) / elapsed
+	}
+	state.throughput.add(tickRate)
+	state.mu.Unlock()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func renderUI(marshaller *runtime.Marshaller) error {
+	imgui.Begin("1BRC - One Billion Row Challenge")
+
+	// File input
+	input := string(bytes.TrimRight(state.inputFile[:], "\x00"))
+	outText, changed := imgui.InputText("Input File", input, imgui.Size_t(len(state.inputFile)))
+	if changed {
+		copy(state.inputFile[:], outText)
+	}
+
+	state.mu.RLock()
+	isProcessing := state.isProcessing
+	state.mu.RUnlock()
+
+	// Calculate button
+	if imgui.Button("Calculate") && !isProcessing {
+		ctx, cancel := context.WithCancel(context.Background())
+		state.mu.Lock()
+		state.isProcessing = true
+		state.cancel = cancel
+		state.mu.Unlock()
+
+		go func() {
+			inputFile := string(bytes.TrimRight(state.inputFile[:], "\x00"))
+			err := calculateStats(ctx, inputFile)
+			state.mu.Lock()
+			if err != nil && err != context.Canceled {
+				state.error = err.Error()
+			}
+			state.isProcessing = false
+			state.cancel = nil
+			state.mu.Unlock()
+		}()
+	}
+
+	state.mu.RLock()
+	fileSize := state.fileSize
+	fileSizeHuman := state.fileSizeHuman
+	bytesRead := state.bytesRead
+	rowsProcessed := state.rowsProcessed
+	rowsPerSecond := state.rowsPerSecond
+	throughput := state.throughput.values()
+	errText := state.error
+	isProcessing = state.isProcessing
+	stations := make([]engine.Station, len(state.stations))
+	copy(stations, state.stations)
+	sortBy := state.sortBy
+	timings := state.phaseTimings
+	state.mu.RUnlock()
+
+	// Show processing indicator, progress bar and a Cancel button
+	if isProcessing {
+		fraction := float32(0)
+		if fileSize > 0 {
+			fraction = float32(bytesRead) / float32(fileSize)
+		}
+		imgui.ProgressBar(fraction, fmt.Sprintf("%d / %s", bytesRead, fileSizeHuman))
+		imgui.Text(fmt.Sprintf("Processing... %d rows (%.0f rows/sec)", rowsProcessed, rowsPerSecond))
+		imgui.PlotLines("Throughput (rows/sec)", throughput)
+
+		if imgui.Button("Cancel") {
+			state.mu.RLock()
+			cancel := state.cancel
+			state.mu.RUnlock()
+			if cancel != nil {
+				cancel()
+			}
+		}
+	}
+
+	// Show error if any
+	if errText != "" {
+		imgui.Text(errText)
+	}
+
+	// Results section
+	if len(stations) > 0 {
+		imgui.Separator()
+		imgui.Text(fmt.Sprintf("Number of Stations: %d", len(stations)))
+		imgui.Text(fmt.Sprintf("File Size: %s", fileSizeHuman))
+		imgui.Text(fmt.Sprintf("Read: %dms  Parse: %dms  Merge: %dms", timings.readMs, timings.parseMs, timings.mergeMs))
+
+		newSortBy, sortChanged := imgui.Combo("Sort by", sortOptions, sortBy)
+		if sortChanged {
+			state.mu.Lock()
+			state.sortBy = newSortBy
+			state.mu.Unlock()
+			sortBy = newSortBy
+		}
+
+		// Top stations table
+		if imgui.BeginTable("Stations", 6) {
+			sortedStations := make([]engine.Station, len(stations))
+			copy(sortedStations, stations)
+			sort.Slice(sortedStations, func(i, j int) bool {
+				a, b := sortedStations[i], sortedStations[j]
+				switch sortOptions[sortBy] {
+				case "Name":
+					return a.Name < b.Name
+				case "Min":
+					return a.Min < b.Min
+				case "Max":
+					return a.Max < b.Max
+				case "Mean":
+					return a.Mean < b.Mean
+				case "Count":
+					return a.Count > b.Count
+				default: // "Range"
+					return (b.Max - b.Min) < (a.Max - a.Min)
+				}
+			})
+
+			// Show table headers
+			imgui.TableSetupColumn("Station")
+			imgui.TableSetupColumn("Min °C")
+			imgui.TableSetupColumn("Max °C")
+			imgui.TableSetupColumn("Mean °C")
+			imgui.TableSetupColumn("Range °C")
+			imgui.TableSetupColumn("Count")
+			imgui.TableHeadersRow()
+
+			// Show top 10 stations
+			for i := 0; i < min(10, len(sortedStations)); i++ {
+				station := sortedStations[i]
+				imgui.TableNextRow()
+				imgui.TableNextColumn()
+				imgui.Text(station.Name)
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%.1f", station.Min))
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%.1f", station.Max))
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%.1f", station.Mean))
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%.1f", station.Max-station.Min))
+				imgui.TableNextColumn()
+				imgui.Text(fmt.Sprintf("%d", station.Count))
+			}
+			imgui.EndTable()
+		}
+	}
+
+	imgui.End()
+	return nil
+}
+
+func main() {
+	// Create application configuration
+	cfg := &application.Config{
+		UseWasm:              false,
+		ImGuiBinary:          "", // We're not using a separate binary
+		MainFontTTF:          "", // Default font
+		MainFontSizeInPixels: 16,
+		MaxRelaunches:        1,
+	}
+
+	// Create and initialize the application
+	app, err := application.NewApplication(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create application: %v", err))
+	}
+
+	// Set the render handler
+	app.RenderLoopHandler = renderUI
+
+	// Launch the application
+	err = app.Launch()
+	if err != nil {
+		panic(fmt.Sprintf("Failed to launch application: %v", err))
+	}
+
+	// Run the application
+	err = app.Run()
+	if err != nil {
+		panic(fmt.Sprintf("Failed to run application: %v", err))
+	}
+}
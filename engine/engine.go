@@ -0,0 +1,217 @@
+// Package engine implements the chunked, parallel row-scanning pipeline
+// shared by the CLI and the GUI: splitting the input into newline-aligned
+// chunks, scanning each chunk into a station hashtable, and merging
+// worker snapshots into a single aggregate. Keeping this in one package
+// means both front ends observe the same semantics instead of drifting
+// apart as each grows its own copy.
+package engine
+
+import (
+	"sort"
+
+	"github.com/OnlyOneCookie/.transfer/units"
+)
+
+// Result holds the aggregated statistics for a weather station in
+// fixed-point tenths-of-a-degree, matching the 1BRC format invariant
+// (one fractional digit, range -99.9..99.9).
+type Result struct {
+	Min   int16
+	Max   int16
+	Sum   int64
+	Count int64
+}
+
+// Station holds a weather station's statistics converted back to
+// floating-point degrees, sorted by name.
+type Station struct {
+	Name  string
+	Min   float64
+	Max   float64
+	Mean  float64
+	Count int64
+}
+
+// Chunk is a newline-aligned byte range within the input.
+type Chunk struct {
+	Start int64
+	End   int64
+}
+
+// ParseTemp parses a fixed-point temperature reading into tenths of a
+// degree. It relies on the 1BRC format invariant (exactly one fractional
+// digit) so it never needs to locate the decimal point: every digit,
+// before or after it, just shifts the accumulator by one more power of
+// ten, and the '.' byte is simply skipped.
+func ParseTemp(s []byte) int16 {
+	neg := false
+	i := 0
+	if s[0] == '-' {
+		neg = true
+		i = 1
+	}
+
+	var v int16
+	for ; i < len(s); i++ {
+		c := s[i]
+		if c == '.' {
+			continue
+		}
+		v = v*10 + int16(c-'0')
+	}
+
+	if neg {
+		return -v
+	}
+	return v
+}
+
+// SplitChunks divides data into newline-aligned ranges so each worker
+// gets a contiguous []byte view to scan independently. If
+// targetChunkSize is 0, data is split evenly across numChunks (with a
+// 1 MiB floor); otherwise targetChunkSize is used directly.
+func SplitChunks(data []byte, numChunks int, targetChunkSize int64) []Chunk {
+	size := int64(len(data))
+
+	var chunkSize int64
+	if targetChunkSize > 0 {
+		chunkSize = targetChunkSize
+	} else {
+		if numChunks < 1 {
+			numChunks = 1
+		}
+		chunkSize = size / int64(numChunks)
+		if chunkSize < units.MiB {
+			chunkSize = units.MiB
+		}
+	}
+
+	var chunks []Chunk
+	start := int64(0)
+	for start < size {
+		end := start + chunkSize
+		if end >= size {
+			end = size
+		} else {
+			for end < size && data[end-1] != '\n' {
+				end++
+			}
+		}
+		chunks = append(chunks, Chunk{Start: start, End: end})
+		start = end
+	}
+	return chunks
+}
+
+// ScanChunk scans a newline-aligned byte range and returns the full
+// per-station table for it. Station names are kept as slices into data,
+// so no allocation happens per row. Alongside the table it returns, it
+// keeps a second one holding only the rows seen since the last flush;
+// every flushRows rows (and once more at the end, if anything is left)
+// that delta table is handed to onFlush and replaced with a fresh one,
+// so callers can merge each flush additively into a shared aggregate
+// without needing to know how many chunks a worker ends up processing.
+//
+// onFlush's return value controls whether scanning continues; returning
+// false stops the scan early (e.g. in response to cancellation), in
+// which case the full table reflects only the rows scanned so far.
+// onParseError, if non-nil, is called once per row with no temperature
+// value instead of a count the caller doesn't need.
+func ScanChunk(data []byte, flushRows int, onFlush func(delta *StationTable, rows, bytesRead int) bool, onParseError func()) *StationTable {
+	full := NewStationTable()
+	delta := NewStationTable()
+
+	rowsSinceFlush := 0
+	bytesSinceFlush := 0
+
+	pos := 0
+	for pos < len(data) {
+		semicolon := pos
+		for data[semicolon] != ';' {
+			semicolon++
+		}
+
+		name := data[pos:semicolon]
+
+		tempStart := semicolon + 1
+		tempEnd := tempStart
+		for tempEnd < len(data) && data[tempEnd] != '\n' {
+			tempEnd++
+		}
+
+		if tempEnd == tempStart {
+			if onParseError != nil {
+				onParseError()
+			}
+			pos = tempEnd + 1
+			continue
+		}
+
+		temp := ParseTemp(data[tempStart:tempEnd])
+		hash := fnv1a(name)
+		full.Add(name, hash, temp)
+		delta.Add(name, hash, temp)
+
+		rowBytes := tempEnd + 1 - pos
+		pos = tempEnd + 1
+		rowsSinceFlush++
+		bytesSinceFlush += rowBytes
+
+		if rowsSinceFlush >= flushRows {
+			cont := onFlush(delta, rowsSinceFlush, bytesSinceFlush)
+			delta = NewStationTable()
+			rowsSinceFlush, bytesSinceFlush = 0, 0
+			if !cont {
+				return full
+			}
+		}
+	}
+
+	if rowsSinceFlush > 0 {
+		onFlush(delta, rowsSinceFlush, bytesSinceFlush)
+	}
+
+	return full
+}
+
+// MergeTableInto folds every station in t additively into dst. It's
+// called once per flush, so it stays cheap: cost is proportional to the
+// (small) number of distinct stations in that flush, not the number of
+// rows. Because a ScanChunk flush carries only the rows seen since the
+// previous one, folding every flush in as it arrives reconstructs the
+// exact running total regardless of how many chunks end up assigned to
+// the same worker.
+func MergeTableInto(dst map[string]*Result, t *StationTable) {
+	t.Each(func(s *StationStats) {
+		if r, exists := dst[string(s.Name)]; exists {
+			if s.Min < r.Min {
+				r.Min = s.Min
+			}
+			if s.Max > r.Max {
+				r.Max = s.Max
+			}
+			r.Sum += s.Sum
+			r.Count += s.Count
+		} else {
+			dst[string(s.Name)] = &Result{Min: s.Min, Max: s.Max, Sum: s.Sum, Count: s.Count}
+		}
+	})
+}
+
+// StationsFromResults converts a result map into a slice sorted by name.
+func StationsFromResults(results map[string]*Result) []Station {
+	stations := make([]Station, 0, len(results))
+	for name, r := range results {
+		stations = append(stations, Station{
+			Name:  name,
+			Min:   float64(r.Min) / 10,
+			Max:   float64(r.Max) / 10,
+			Mean:  float64(r.Sum) / 10 / float64(r.Count),
+			Count: r.Count,
+		})
+	}
+	sort.Slice(stations, func(i, j int) bool {
+		return stations[i].Name < stations[j].Name
+	})
+	return stations
+}
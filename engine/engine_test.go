@@ -0,0 +1,113 @@
+package engine
+
+import "testing"
+
+func TestParseTemp(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int16
+	}{
+		{"0.0", 0},
+		{"-0.0", 0},
+		{"25.3", 253},
+		{"-25.3", -253},
+		{"99.9", 999},
+		{"-99.9", -999},
+		{"5.0", 50},
+	}
+	for _, c := range cases {
+		if got := ParseTemp([]byte(c.in)); got != c.want {
+			t.Errorf("ParseTemp(%q) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+// TestMergeTableIntoAcrossMultipleFlushes guards the invariant ScanChunk's
+// delta/full split depends on: every flush from the same chunk (or the
+// same worker, across chunks) must be merged additively, not replace a
+// previous flush's contribution. A keyed-replace merge keyed by worker or
+// chunk identity silently drops earlier flushes whenever more than one
+// flush happens before the final aggregate is read - exactly the bug this
+// test would have caught before it shipped.
+func TestMergeTableIntoAcrossMultipleFlushes(t *testing.T) {
+	data := []byte("Berlin;10.0\nBerlin;20.0\nBerlin;-5.0\nParis;1.0\n")
+
+	var flushes []*StationTable
+	full := ScanChunk(data, 1, func(delta *StationTable, rows, bytesRead int) bool {
+		flushes = append(flushes, delta)
+		return true
+	}, nil)
+
+	if len(flushes) != 4 {
+		t.Fatalf("got %d flushes with flushRows=1, want 4", len(flushes))
+	}
+
+	dst := make(map[string]*Result)
+	for _, delta := range flushes {
+		MergeTableInto(dst, delta)
+	}
+
+	berlin, ok := dst["Berlin"]
+	if !ok {
+		t.Fatal("Berlin missing from merged result")
+	}
+	if berlin.Count != 3 {
+		t.Errorf("Berlin.Count = %d, want 3 (one per flush merged additively)", berlin.Count)
+	}
+	if berlin.Sum != 250 {
+		t.Errorf("Berlin.Sum = %d, want 250 (10.0+20.0-5.0 in tenths)", berlin.Sum)
+	}
+	if berlin.Min != -50 {
+		t.Errorf("Berlin.Min = %d, want -50", berlin.Min)
+	}
+	if berlin.Max != 200 {
+		t.Errorf("Berlin.Max = %d, want 200", berlin.Max)
+	}
+
+	paris, ok := dst["Paris"]
+	if !ok {
+		t.Fatal("Paris missing from merged result")
+	}
+	if paris.Count != 1 || paris.Sum != 10 {
+		t.Errorf("Paris = %+v, want Count=1 Sum=10", paris)
+	}
+
+	// The full table returned alongside the flushes must match the merged
+	// aggregate, since it's meant to reflect every row regardless of how
+	// many times the delta table was flushed and reset.
+	var fullBerlin *StationStats
+	full.Each(func(s *StationStats) {
+		if string(s.Name) == "Berlin" {
+			fullBerlin = s
+		}
+	})
+	if fullBerlin == nil {
+		t.Fatal("Berlin missing from full table")
+	}
+	if fullBerlin.Count != berlin.Count || fullBerlin.Sum != berlin.Sum {
+		t.Errorf("full table Berlin = %+v, want Count=%d Sum=%d", fullBerlin, berlin.Count, berlin.Sum)
+	}
+}
+
+func TestMergeTableIntoAcrossSeparateTables(t *testing.T) {
+	dst := make(map[string]*Result)
+
+	a := NewStationTable()
+	a.Add([]byte("Cairo"), fnv1a([]byte("Cairo")), 300)
+	MergeTableInto(dst, a)
+
+	b := NewStationTable()
+	b.Add([]byte("Cairo"), fnv1a([]byte("Cairo")), -10)
+	MergeTableInto(dst, b)
+
+	cairo := dst["Cairo"]
+	if cairo.Count != 2 {
+		t.Errorf("Count = %d, want 2", cairo.Count)
+	}
+	if cairo.Sum != 290 {
+		t.Errorf("Sum = %d, want 290", cairo.Sum)
+	}
+	if cairo.Min != -10 || cairo.Max != 300 {
+		t.Errorf("Min/Max = %d/%d, want -10/300", cairo.Min, cairo.Max)
+	}
+}
@@ -0,0 +1,110 @@
+package engine
+
+import "bytes"
+
+// StationStats accumulates the running min/max/sum/count for a single
+// station in fixed-point tenths-of-a-degree. Name points directly into
+// the caller's input buffer, so no per-row allocation is needed to key
+// the table.
+type StationStats struct {
+	Name  []byte
+	Hash  uint64
+	Min   int16
+	Max   int16
+	Sum   int64
+	Count int64
+}
+
+// StationTable is an open-addressed hashtable (linear probing) keyed by
+// the FNV-1a hash of the station name. It exists so ScanChunk never has
+// to pay for string(nameBytes) just to do a map lookup.
+type StationTable struct {
+	slots    []StationStats
+	occupied []bool
+	count    int
+}
+
+// NewStationTable returns an empty StationTable sized for a typical
+// station count, growing on demand as rows come in.
+func NewStationTable() *StationTable {
+	const initialSize = 256 // power of two; typical station counts fit with room to spare
+	return &StationTable{
+		slots:    make([]StationStats, initialSize),
+		occupied: make([]bool, initialSize),
+	}
+}
+
+// fnv1a hashes a station name using the 64-bit FNV-1a algorithm.
+func fnv1a(b []byte) uint64 {
+	const (
+		offsetBasis = 14695981039346656037
+		prime       = 1099511628211
+	)
+	h := uint64(offsetBasis)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= prime
+	}
+	return h
+}
+
+// Add records one temperature reading for name, growing the table if the
+// load factor would exceed 50%.
+func (t *StationTable) Add(name []byte, hash uint64, temp int16) {
+	mask := uint64(len(t.slots) - 1)
+	idx := hash & mask
+	for {
+		if !t.occupied[idx] {
+			t.occupied[idx] = true
+			t.slots[idx] = StationStats{Name: name, Hash: hash, Min: temp, Max: temp, Sum: int64(temp), Count: 1}
+			t.count++
+			if t.count*2 > len(t.slots) {
+				t.grow()
+			}
+			return
+		}
+		if t.slots[idx].Hash == hash && bytes.Equal(t.slots[idx].Name, name) {
+			s := &t.slots[idx]
+			if temp < s.Min {
+				s.Min = temp
+			}
+			if temp > s.Max {
+				s.Max = temp
+			}
+			s.Sum += int64(temp)
+			s.Count++
+			return
+		}
+		idx = (idx + 1) & mask
+	}
+}
+
+func (t *StationTable) grow() {
+	oldSlots, oldOccupied := t.slots, t.occupied
+	t.slots = make([]StationStats, len(oldSlots)*2)
+	t.occupied = make([]bool, len(oldOccupied)*2)
+	t.count = 0
+	mask := uint64(len(t.slots) - 1)
+	for i, occupied := range oldOccupied {
+		if !occupied {
+			continue
+		}
+		s := oldSlots[i]
+		idx := s.Hash & mask
+		for t.occupied[idx] {
+			idx = (idx + 1) & mask
+		}
+		t.occupied[idx] = true
+		t.slots[idx] = s
+		t.count++
+	}
+}
+
+// Each calls fn for every occupied slot in the table.
+func (t *StationTable) Each(fn func(s *StationStats)) {
+	for i, occupied := range t.occupied {
+		if occupied {
+			fn(&t.slots[i])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package engine
+
+import "testing"
+
+func TestStationTableAddAccumulates(t *testing.T) {
+	tbl := NewStationTable()
+	readings := []int16{10, -40, 250, 0}
+	for _, temp := range readings {
+		tbl.Add([]byte("Berlin"), fnv1a([]byte("Berlin")), temp)
+	}
+
+	var got *StationStats
+	tbl.Each(func(s *StationStats) {
+		got = s
+	})
+	if got == nil {
+		t.Fatal("expected one station, got none")
+	}
+	if got.Min != -40 {
+		t.Errorf("Min = %d, want -40", got.Min)
+	}
+	if got.Max != 250 {
+		t.Errorf("Max = %d, want 250", got.Max)
+	}
+	if want := int64(10 - 40 + 250 + 0); got.Sum != want {
+		t.Errorf("Sum = %d, want %d", got.Sum, want)
+	}
+	if got.Count != int64(len(readings)) {
+		t.Errorf("Count = %d, want %d", got.Count, len(readings))
+	}
+}
+
+func TestStationTableAddKeepsNamesDistinct(t *testing.T) {
+	tbl := NewStationTable()
+	tbl.Add([]byte("Berlin"), fnv1a([]byte("Berlin")), 100)
+	tbl.Add([]byte("Bern"), fnv1a([]byte("Bern")), -50)
+
+	seen := map[string]int64{}
+	tbl.Each(func(s *StationStats) {
+		seen[string(s.Name)] = s.Sum
+	})
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 distinct stations, got %d: %v", len(seen), seen)
+	}
+	if seen["Berlin"] != 100 {
+		t.Errorf("Berlin sum = %d, want 100", seen["Berlin"])
+	}
+	if seen["Bern"] != -50 {
+		t.Errorf("Bern sum = %d, want -50", seen["Bern"])
+	}
+}
+
+func TestStationTableGrowsAndKeepsEveryEntry(t *testing.T) {
+	tbl := NewStationTable()
+
+	const stationCount = 1000 // well past the initial 256-slot table's 50% load factor
+	for i := 0; i < stationCount; i++ {
+		name := []byte{byte('A' + i%26), byte('a' + (i/26)%26), byte('0' + i%10)}
+		tbl.Add(name, fnv1a(name), int16(i))
+	}
+
+	seen := 0
+	tbl.Each(func(s *StationStats) {
+		seen++
+	})
+	if seen != stationCount {
+		t.Fatalf("Each visited %d stations after grow, want %d", seen, stationCount)
+	}
+}
@@ -0,0 +1,77 @@
+// Package units formats and parses byte sizes using binary units (KiB,
+// MiB, ...), in the style of bytefmt.ByteSize.
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	_   = iota
+	KiB = 1 << (10 * iota)
+	MiB
+	GiB
+	TiB
+	PiB
+	EiB
+)
+
+// ByteSize formats bytes as a human-readable binary size with one decimal
+// place, e.g. 3623878656 -> "3.4 GiB".
+func ByteSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// ParseByteSize parses a human-readable binary size such as "16MiB",
+// "512KiB" or "100" (bytes) back into a byte count, for flags like
+// --chunk-size.
+func ParseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("units: empty size")
+	}
+
+	i := len(s)
+	for i > 0 && (s[i-1] < '0' || s[i-1] > '9') && s[i-1] != '.' {
+		i--
+	}
+	numPart, unitPart := s[:i], strings.TrimSpace(s[i:])
+
+	value, err := strconv.ParseFloat(numPart, 64)
+	if err != nil {
+		return 0, fmt.Errorf("units: invalid size %q: %v", s, err)
+	}
+
+	var multiplier float64 = 1
+	switch strings.ToUpper(unitPart) {
+	case "", "B":
+		multiplier = 1
+	case "K", "KB", "KIB":
+		multiplier = KiB
+	case "M", "MB", "MIB":
+		multiplier = MiB
+	case "G", "GB", "GIB":
+		multiplier = GiB
+	case "T", "TB", "TIB":
+		multiplier = TiB
+	case "P", "PB", "PIB":
+		multiplier = PiB
+	case "E", "EB", "EIB":
+		multiplier = EiB
+	default:
+		return 0, fmt.Errorf("units: unknown unit %q in %q", unitPart, s)
+	}
+
+	return int64(value * multiplier), nil
+}